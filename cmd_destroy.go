@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/jbpratt/stk-memes/internal/node"
+)
+
+func cmdDestroy(args []string) error {
+	fs := flag.NewFlagSet("destroy", flag.ExitOnError)
+	cfgPath := fs.String("path", "", "path to config file")
+	name := fs.String("name", "", "name of the deployment")
+	statePath := fs.String("state", "", "path to state file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *name == "" {
+		return fmt.Errorf("destroy: -name is required")
+	}
+
+	store, err := openStore(*statePath)
+	if err != nil {
+		return err
+	}
+
+	record, ok := store.Get(*name)
+	if !ok {
+		return fmt.Errorf("no deployment named %q", *name)
+	}
+
+	config, err := loadConfig(*cfgPath)
+	if err != nil {
+		return err
+	}
+
+	driver, err := node.New(record.Provider, config.Credentials)
+	if err != nil {
+		return err
+	}
+
+	if err := driver.Destroy(context.Background(), record.NodeID); err != nil {
+		return fmt.Errorf("failed to destroy %q: %w", *name, err)
+	}
+
+	return store.Delete(*name)
+}
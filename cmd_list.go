@@ -0,0 +1,30 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+)
+
+func cmdList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	statePath := fs.String("state", "", "path to state file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	store, err := openStore(*statePath)
+	if err != nil {
+		return err
+	}
+
+	records := store.List()
+	sort.Slice(records, func(i, j int) bool { return records[i].Name < records[j].Name })
+
+	for _, r := range records {
+		fmt.Printf("%s\tprovider=%s\thost=%s\tcreated=%s\n",
+			r.Name, r.Provider, r.Host, r.CreatedAt.Format("2006-01-02T15:04:05Z07:00"))
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+func cmdSSH(args []string) error {
+	fs := flag.NewFlagSet("ssh", flag.ExitOnError)
+	name := fs.String("name", "", "name of the deployment")
+	statePath := fs.String("state", "", "path to state file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *name == "" {
+		return fmt.Errorf("ssh: -name is required")
+	}
+
+	store, err := openStore(*statePath)
+	if err != nil {
+		return err
+	}
+
+	record, ok := store.Get(*name)
+	if !ok {
+		return fmt.Errorf("no deployment named %q", *name)
+	}
+
+	cmd := exec.Command("ssh", "-i", record.IdentityFile, fmt.Sprintf("%s@%s", record.User, record.Host))
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/jbpratt/stk-memes/internal/node"
+)
+
+func cmdStatus(args []string) error {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	cfgPath := fs.String("path", "", "path to config file")
+	name := fs.String("name", "", "name of the deployment")
+	statePath := fs.String("state", "", "path to state file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *name == "" {
+		return fmt.Errorf("status: -name is required")
+	}
+
+	store, err := openStore(*statePath)
+	if err != nil {
+		return err
+	}
+
+	record, ok := store.Get(*name)
+	if !ok {
+		return fmt.Errorf("no deployment named %q", *name)
+	}
+
+	config, err := loadConfig(*cfgPath)
+	if err != nil {
+		return err
+	}
+
+	driver, err := node.New(record.Provider, config.Credentials)
+	if err != nil {
+		return err
+	}
+
+	n, err := driver.Get(context.Background(), record.NodeID)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile %q: %w", *name, err)
+	}
+
+	fmt.Printf("%s\tprovider=%s\tid=%s\thost=%s\tcreated=%s\n",
+		record.Name, record.Provider, n.ID, strings.Join(n.Networks.V4, ","), record.CreatedAt.Format("2006-01-02T15:04:05Z07:00"))
+
+	return nil
+}
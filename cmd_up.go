@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"go.starlark.net/starlark"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/jbpratt/stk-memes/internal/cloudinit"
+	"github.com/jbpratt/stk-memes/internal/node"
+	"github.com/jbpratt/stk-memes/internal/prompt"
+	"github.com/jbpratt/stk-memes/internal/script"
+	"github.com/jbpratt/stk-memes/internal/sshx"
+	"github.com/jbpratt/stk-memes/internal/state"
+)
+
+func cmdUp(args []string) error {
+	fs := flag.NewFlagSet("up", flag.ExitOnError)
+	cfgPath := fs.String("path", "", "path to config file")
+	scriptPath := fs.String("script", "stk.star", "path to provisioning script")
+	name := fs.String("name", "stk-memes", "name to store this deployment under")
+	statePath := fs.String("state", "", "path to state file (default ~/.config/stk-memes/state.json)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	config, err := loadConfig(*cfgPath)
+	if err != nil {
+		return err
+	}
+
+	if config.STKPassword == "" {
+		secret, err := prompt.AskSecret("STK server password: ")
+		if err != nil {
+			return err
+		}
+		config.STKPassword = string(secret)
+	}
+
+	store, err := openStore(*statePath)
+	if err != nil {
+		return err
+	}
+
+	d, err := os.ReadFile(config.IdentityFile + ".pub")
+	if err != nil {
+		return fmt.Errorf("error reading ssh public key: %w", err)
+	}
+	pubkey := string(bytes.Trim(d, "\r\n\t "))
+
+	privkey, err := os.ReadFile(config.IdentityFile)
+	if err != nil {
+		return fmt.Errorf("failed to read priv key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(privkey)
+	if err != nil {
+		return fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	driver, err := node.New(config.Provider, config.Credentials)
+	if err != nil {
+		return err
+	}
+
+	knownHosts, err := knownHostsPath(*statePath)
+	if err != nil {
+		return err
+	}
+
+	hostKeyCB, err := sshx.TOFUCallback(knownHosts)
+	if err != nil {
+		return err
+	}
+
+	userData, err := cloudinit.Render(driver.DefaultUser())
+	if err != nil {
+		return err
+	}
+
+	globals := starlark.StringDict{
+		"SSH_KEY":      starlark.String(pubkey),
+		"STK_USERNAME": starlark.String(config.STKUsername),
+		"STK_PASSWORD": starlark.String(config.STKPassword),
+		"USER_DATA":    starlark.String(userData),
+	}
+
+	runner := script.NewRunner(context.Background(), config.Provider, driver, driver.DefaultUser(), signer, hostKeyCB)
+
+	log.Println("running", *scriptPath)
+	if err := runner.Run(*scriptPath, globals); err != nil {
+		return fmt.Errorf("script failed: %w", err)
+	}
+
+	nodes := runner.Nodes()
+	if len(nodes) == 0 {
+		return fmt.Errorf("script created no nodes, nothing to record")
+	}
+
+	n := nodes[0]
+	if len(nodes) > 1 {
+		log.Printf("script created %d nodes, only recording %q under name %q\n", len(nodes), n.ID, *name)
+	}
+	if len(n.Networks.V4) == 0 {
+		return fmt.Errorf("node %s has no public IP to record", n.ID)
+	}
+
+	record := state.Record{
+		Name:         *name,
+		Provider:     config.Provider,
+		NodeID:       n.ID,
+		Host:         n.Networks.V4[0],
+		User:         driver.DefaultUser(),
+		IdentityFile: config.IdentityFile,
+		CreatedAt:    time.Now(),
+	}
+	if err := store.Put(record); err != nil {
+		return fmt.Errorf("failed to save deployment state: %w", err)
+	}
+
+	log.Printf("deployment %q up at %s\n", *name, record.Host)
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/jbpratt/stk-memes/internal/node"
+	"github.com/jbpratt/stk-memes/internal/state"
+)
+
+func init() {
+	node.Register("mock", func(credentials map[string]string) (node.Driver, error) {
+		return node.NewMockDriver(), nil
+	})
+}
+
+// writeTestIdentity generates an ed25519 keypair and writes it to dir as
+// id_test/id_test.pub, the shape cmdUp expects for -path's identity_file.
+func writeTestIdentity(t *testing.T, dir string) string {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatalf("marshal private key: %v", err)
+	}
+
+	identityFile := filepath.Join(dir, "id_test")
+	if err := os.WriteFile(identityFile, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("write private key: %v", err)
+	}
+
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("derive public key: %v", err)
+	}
+	if err := os.WriteFile(identityFile+".pub", ssh.MarshalAuthorizedKey(sshPub), 0o644); err != nil {
+		t.Fatalf("write public key: %v", err)
+	}
+
+	return identityFile
+}
+
+func TestCmdUpAgainstMockDriver(t *testing.T) {
+	dir := t.TempDir()
+
+	identityFile := writeTestIdentity(t, dir)
+
+	scriptPath := filepath.Join(dir, "mock.star")
+	script := `
+server = node(
+    provider = "mock",
+    region = "test",
+    sku = "test",
+    name = "mock-node",
+    ssh_key = SSH_KEY,
+    user_data = USER_DATA,
+)
+`
+	if err := os.WriteFile(scriptPath, []byte(script), 0o644); err != nil {
+		t.Fatalf("write script: %v", err)
+	}
+
+	cfgPath := filepath.Join(dir, "config.json")
+	cfg := cfg{
+		IdentityFile: identityFile,
+		Provider:     "mock",
+		STKUsername:  "stk",
+		STKPassword:  "unused-in-this-test",
+	}
+	contents, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("marshal config: %v", err)
+	}
+	if err := os.WriteFile(cfgPath, contents, 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	statePath := filepath.Join(dir, "state.json")
+
+	if err := cmdUp([]string{
+		"-path", cfgPath,
+		"-script", scriptPath,
+		"-name", "mock-deployment",
+		"-state", statePath,
+	}); err != nil {
+		t.Fatalf("cmdUp: %v", err)
+	}
+
+	store, err := state.Open(statePath)
+	if err != nil {
+		t.Fatalf("reopen state: %v", err)
+	}
+
+	record, ok := store.Get("mock-deployment")
+	if !ok {
+		t.Fatal("state: expected a record for \"mock-deployment\", found none")
+	}
+	if record.Provider != "mock" {
+		t.Errorf("record.Provider = %q, want %q", record.Provider, "mock")
+	}
+	if record.Host != "10.0.0.1" {
+		t.Errorf("record.Host = %q, want %q", record.Host, "10.0.0.1")
+	}
+}
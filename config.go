@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+type cfg struct {
+	IdentityFile string            `json:"identity_file"`
+	Provider     string            `json:"provider"`
+	Credentials  map[string]string `json:"credentials"`
+	STKUsername  string            `json:"stk_username"`
+	// STKPassword is optional; if it's left out of the config, cmdUp
+	// prompts for it instead of requiring it on disk in plaintext.
+	STKPassword string `json:"stk_password"`
+}
+
+func loadConfig(path string) (*cfg, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cfg file %s: %w", path, err)
+	}
+
+	config := &cfg{}
+	if err := json.Unmarshal(contents, config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cfg contents: %w", err)
+	}
+
+	config.expandEnv()
+
+	return config, nil
+}
+
+// expandEnv expands ${VAR}/$VAR references in string config fields, so
+// secrets can come from a secret manager via the environment instead of
+// sitting in the config file.
+func (c *cfg) expandEnv() {
+	c.IdentityFile = os.ExpandEnv(c.IdentityFile)
+	c.Provider = os.ExpandEnv(c.Provider)
+	c.STKUsername = os.ExpandEnv(c.STKUsername)
+	c.STKPassword = os.ExpandEnv(c.STKPassword)
+	for k, v := range c.Credentials {
+		c.Credentials[k] = os.ExpandEnv(v)
+	}
+}
@@ -0,0 +1,83 @@
+// Package cloudinit renders the cloud-init user-data that bootstraps an
+// stk-memes server, so provisioning runs as a single declarative document
+// the provider hands to the instance rather than commands streamed over an
+// interactive SSH session. It builds the STK server binary and installs
+// its config, but deliberately stops short of creating the STK user: that
+// needs the server password, which must never end up in user-data, since
+// it's readable back from the instance metadata service and is stored by
+// the provider alongside the rest of the instance's config. The caller
+// provisions that user afterwards, over SSH.
+package cloudinit
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+var tmpl = template.Must(template.New("user-data").Parse(userDataTemplate))
+
+// Render produces the cloud-init user-data YAML for bootstrapping an
+// stk-memes server. user is the account that will SSH in and run
+// supertuxkart afterwards (driver.DefaultUser()), so its server_config.xml
+// lands under that user's home directory rather than root's.
+func Render(user string) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ Home string }{Home: homeDir(user)}); err != nil {
+		return "", fmt.Errorf("cloudinit: failed to render user-data: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// homeDir returns the home directory a stock Ubuntu image gives user.
+func homeDir(user string) string {
+	if user == "root" {
+		return "/root"
+	}
+
+	return "/home/" + user
+}
+
+const userDataTemplate = `#cloud-config
+package_update: true
+package_upgrade: true
+packages:
+  - build-essential
+  - subversion
+  - cmake
+  - libbluetooth-dev
+  - libsdl2-dev
+  - libcurl4-openssl-dev
+  - libenet-dev
+  - libfreetype6-dev
+  - libharfbuzz-dev
+  - libjpeg-dev
+  - libogg-dev
+  - libopenal-dev
+  - libpng-dev
+  - libssl-dev
+  - libvorbis-dev
+  - nettle-dev
+  - pkg-config
+  - zlib1g-dev
+
+write_files:
+  - path: {{ .Home }}/.config/supertuxkart/config-0.10/server_config.xml
+    permissions: '0644'
+    content: |
+      <?xml version="1.0"?>
+      <server-config version="4" >
+        <server-name value="stk-memes" />
+        <server-port value="2759" />
+        <private-server value="false" />
+      </server-config>
+
+runcmd:
+  - mkdir -p /root/stk
+  - git clone https://github.com/supertuxkart/stk-code /root/stk/stk-code
+  - svn co https://svn.code.sf.net/p/supertuxkart/code/stk-assets /root/stk/stk-assets
+  - cmake -S /root/stk/stk-code -B /root/stk/stk-code/cmake_build -DSERVER_ONLY=ON
+  - make -C /root/stk/stk-code/cmake_build install
+  - ufw allow 2759
+`
@@ -0,0 +1,147 @@
+// Package digitalocean implements a node.Driver backed by the DigitalOcean API.
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+	"golang.org/x/oauth2"
+
+	"github.com/jbpratt/stk-memes/internal/node"
+)
+
+func init() {
+	node.Register("digitalocean", func(credentials map[string]string) (node.Driver, error) {
+		return NewDriver(credentials["token"]), nil
+	})
+}
+
+// Driver provisions nodes against the DigitalOcean API.
+type Driver struct {
+	client *godo.Client
+}
+
+type tokenSource struct {
+	token string
+}
+
+func (t *tokenSource) Token() (*oauth2.Token, error) {
+	return &oauth2.Token{AccessToken: t.token}, nil
+}
+
+// NewDriver builds a Driver authenticated with the given API token.
+func NewDriver(token string) *Driver {
+	oauthClient := oauth2.NewClient(context.Background(), &tokenSource{token: token})
+	return &Driver{client: godo.NewClient(oauthClient)}
+}
+
+// DefaultUser returns the username DigitalOcean's stock Ubuntu images log in as.
+func (d *Driver) DefaultUser() string {
+	return "root"
+}
+
+// ensureSSHKey makes sure an SSH key named name is registered on the
+// account, registering publicKey under that name if necessary, and returns
+// its fingerprint. An existing key is matched by name only; its content is
+// not compared with publicKey. DropletCreateSSHKey.Fingerprint must
+// reference a key already uploaded to the account, not the raw key
+// content, so the key has to exist as an account resource first.
+func (d *Driver) ensureSSHKey(ctx context.Context, name, publicKey string) (string, error) {
+	keys, _, err := d.client.Keys.List(ctx, &godo.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list ssh keys: %w", err)
+	}
+	for _, k := range keys {
+		if k.Name == name {
+			return k.Fingerprint, nil
+		}
+	}
+
+	key, _, err := d.client.Keys.Create(ctx, &godo.KeyCreateRequest{Name: name, PublicKey: publicKey})
+	if err != nil {
+		return "", fmt.Errorf("failed to register ssh key %s: %w", name, err)
+	}
+
+	return key.Fingerprint, nil
+}
+
+// Create provisions a new droplet.
+func (d *Driver) Create(ctx context.Context, req *node.CreateRequest) (*node.Node, error) {
+	var sshKeys []godo.DropletCreateSSHKey
+	if req.SSHKey != "" {
+		fingerprint, err := d.ensureSSHKey(ctx, req.Name, req.SSHKey)
+		if err != nil {
+			return nil, err
+		}
+		sshKeys = []godo.DropletCreateSSHKey{{Fingerprint: fingerprint}}
+	}
+
+	droplet, _, err := d.client.Droplets.Create(ctx, &godo.DropletCreateRequest{
+		Name:     req.Name,
+		Region:   req.Region,
+		Size:     req.SKU,
+		Image:    godo.DropletCreateImage{Slug: "ubuntu-20-04-x64"},
+		SSHKeys:  sshKeys,
+		UserData: req.UserData,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create droplet: %w", err)
+	}
+
+	return toNode(droplet), nil
+}
+
+// Destroy deletes the droplet with the given ID.
+func (d *Driver) Destroy(ctx context.Context, id string) error {
+	dropletID, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("invalid droplet id %q: %w", id, err)
+	}
+
+	if _, err := d.client.Droplets.Delete(ctx, dropletID); err != nil {
+		return fmt.Errorf("failed to delete droplet %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// List returns every droplet on the account.
+func (d *Driver) List(ctx context.Context) ([]*node.Node, error) {
+	droplets, _, err := d.client.Droplets.List(ctx, &godo.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list droplets: %w", err)
+	}
+
+	nodes := make([]*node.Node, 0, len(droplets))
+	for i := range droplets {
+		nodes = append(nodes, toNode(&droplets[i]))
+	}
+
+	return nodes, nil
+}
+
+// Get looks up a single droplet by ID.
+func (d *Driver) Get(ctx context.Context, id string) (*node.Node, error) {
+	dropletID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid droplet id %q: %w", id, err)
+	}
+
+	droplet, _, err := d.client.Droplets.Get(ctx, dropletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get droplet %s: %w", id, err)
+	}
+
+	return toNode(droplet), nil
+}
+
+func toNode(droplet *godo.Droplet) *node.Node {
+	n := &node.Node{ID: strconv.Itoa(droplet.ID), Name: droplet.Name}
+	if ip, err := droplet.PublicIPv4(); err == nil && ip != "" {
+		n.Networks.V4 = []string{ip}
+	}
+
+	return n
+}
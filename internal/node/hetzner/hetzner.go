@@ -0,0 +1,146 @@
+// Package hetzner implements a node.Driver backed by the Hetzner Cloud API.
+package hetzner
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+
+	"github.com/jbpratt/stk-memes/internal/node"
+)
+
+func init() {
+	node.Register("hetzner", func(credentials map[string]string) (node.Driver, error) {
+		return NewDriver(credentials["token"]), nil
+	})
+}
+
+// Driver provisions nodes against the Hetzner Cloud API.
+type Driver struct {
+	client *hcloud.Client
+}
+
+// NewDriver builds a Driver authenticated with the given API token.
+func NewDriver(token string) *Driver {
+	return &Driver{client: hcloud.NewClient(hcloud.WithToken(token))}
+}
+
+// DefaultUser returns the username Hetzner's stock Ubuntu images log in as.
+func (d *Driver) DefaultUser() string {
+	return "root"
+}
+
+// ensureSSHKey makes sure an SSH key named name is registered on the
+// account, registering publicKey under that name if necessary, and returns
+// it so it can be attached to a Create call. An existing key is matched by
+// name only; its content is not compared with publicKey. Hetzner's
+// ServerCreateOpts.SSHKeys is marshaled by key ID, not public key content,
+// so the key has to exist as an account resource first.
+func (d *Driver) ensureSSHKey(ctx context.Context, name, publicKey string) (*hcloud.SSHKey, error) {
+	key, _, err := d.client.SSHKey.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up ssh key %s: %w", name, err)
+	}
+	if key != nil {
+		return key, nil
+	}
+
+	key, _, err = d.client.SSHKey.Create(ctx, hcloud.SSHKeyCreateOpts{Name: name, PublicKey: publicKey})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register ssh key %s: %w", name, err)
+	}
+
+	return key, nil
+}
+
+// Create provisions a new Hetzner server.
+func (d *Driver) Create(ctx context.Context, req *node.CreateRequest) (*node.Node, error) {
+	var sshKeys []*hcloud.SSHKey
+	if req.SSHKey != "" {
+		key, err := d.ensureSSHKey(ctx, req.Name, req.SSHKey)
+		if err != nil {
+			return nil, err
+		}
+		sshKeys = []*hcloud.SSHKey{key}
+	}
+
+	result, _, err := d.client.Server.Create(ctx, hcloud.ServerCreateOpts{
+		Name:       req.Name,
+		ServerType: &hcloud.ServerType{Name: req.SKU},
+		Image:      &hcloud.Image{Name: "ubuntu-20.04"},
+		Location:   &hcloud.Location{Name: req.Region},
+		SSHKeys:    sshKeys,
+		UserData:   req.UserData,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server: %w", err)
+	}
+
+	return toNode(result.Server), nil
+}
+
+// Destroy deletes the server with the given ID.
+func (d *Driver) Destroy(ctx context.Context, id string) error {
+	serverID, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("invalid server id %q: %w", id, err)
+	}
+
+	server, _, err := d.client.Server.GetByID(ctx, serverID)
+	if err != nil {
+		return fmt.Errorf("failed to look up server %s: %w", id, err)
+	}
+	if server == nil {
+		return fmt.Errorf("no such server %s", id)
+	}
+
+	if _, _, err := d.client.Server.DeleteWithResult(ctx, server); err != nil {
+		return fmt.Errorf("failed to delete server %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// List returns every server on the account.
+func (d *Driver) List(ctx context.Context) ([]*node.Node, error) {
+	servers, err := d.client.Server.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	nodes := make([]*node.Node, 0, len(servers))
+	for _, s := range servers {
+		nodes = append(nodes, toNode(s))
+	}
+
+	return nodes, nil
+}
+
+// Get looks up a single server by ID.
+func (d *Driver) Get(ctx context.Context, id string) (*node.Node, error) {
+	serverID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server id %q: %w", id, err)
+	}
+
+	server, _, err := d.client.Server.GetByID(ctx, serverID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server %s: %w", id, err)
+	}
+	if server == nil {
+		return nil, fmt.Errorf("no such server %s", id)
+	}
+
+	return toNode(server), nil
+}
+
+func toNode(s *hcloud.Server) *node.Node {
+	n := &node.Node{ID: strconv.Itoa(s.ID), Name: s.Name}
+	if s.PublicNet.IPv4.IP != nil {
+		n.Networks.V4 = []string{s.PublicNet.IPv4.IP.String()}
+	}
+
+	return n
+}
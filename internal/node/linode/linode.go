@@ -0,0 +1,115 @@
+// Package linode implements a node.Driver backed by the Linode API.
+package linode
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	"github.com/linode/linodego"
+	"golang.org/x/oauth2"
+
+	"github.com/jbpratt/stk-memes/internal/node"
+)
+
+func init() {
+	node.Register("linode", func(credentials map[string]string) (node.Driver, error) {
+		return NewDriver(credentials["token"]), nil
+	})
+}
+
+// Driver provisions nodes against the Linode API.
+type Driver struct {
+	client linodego.Client
+}
+
+// NewDriver builds a Driver authenticated with the given API token.
+func NewDriver(token string) *Driver {
+	oauthClient := oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	))
+
+	return &Driver{client: linodego.NewClient(oauthClient)}
+}
+
+// DefaultUser returns the username Linode's stock Ubuntu images log in as.
+func (d *Driver) DefaultUser() string {
+	return "root"
+}
+
+// Create provisions a new Linode instance.
+func (d *Driver) Create(ctx context.Context, req *node.CreateRequest) (*node.Node, error) {
+	var metadata *linodego.InstanceMetadataOptions
+	if req.UserData != "" {
+		metadata = &linodego.InstanceMetadataOptions{UserData: base64.StdEncoding.EncodeToString([]byte(req.UserData))}
+	}
+
+	instance, err := d.client.CreateInstance(ctx, linodego.InstanceCreateOptions{
+		Label:          req.Name,
+		Region:         req.Region,
+		Type:           req.SKU,
+		Image:          "linode/ubuntu20.04",
+		AuthorizedKeys: []string{req.SSHKey},
+		Booted:         linodego.Pointer(true),
+		Metadata:       metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create instance: %w", err)
+	}
+
+	return toNode(instance), nil
+}
+
+// Destroy deletes the instance with the given ID.
+func (d *Driver) Destroy(ctx context.Context, id string) error {
+	instanceID, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("invalid instance id %q: %w", id, err)
+	}
+
+	if err := d.client.DeleteInstance(ctx, instanceID); err != nil {
+		return fmt.Errorf("failed to delete instance %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// List returns every instance on the account.
+func (d *Driver) List(ctx context.Context) ([]*node.Node, error) {
+	instances, err := d.client.ListInstances(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instances: %w", err)
+	}
+
+	nodes := make([]*node.Node, 0, len(instances))
+	for i := range instances {
+		nodes = append(nodes, toNode(&instances[i]))
+	}
+
+	return nodes, nil
+}
+
+// Get looks up a single instance by ID.
+func (d *Driver) Get(ctx context.Context, id string) (*node.Node, error) {
+	instanceID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid instance id %q: %w", id, err)
+	}
+
+	instance, err := d.client.GetInstance(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance %s: %w", id, err)
+	}
+
+	return toNode(instance), nil
+}
+
+func toNode(instance *linodego.Instance) *node.Node {
+	n := &node.Node{ID: strconv.Itoa(instance.ID), Name: instance.Label}
+	for _, ip := range instance.IPv4 {
+		n.Networks.V4 = append(n.Networks.V4, ip.String())
+	}
+
+	return n
+}
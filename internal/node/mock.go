@@ -0,0 +1,74 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// MockDriver is an in-memory Driver for use in tests. Create assigns
+// sequential IDs and the fixed IP 10.0.0.1; Destroy, List and Get operate
+// on an in-memory map.
+type MockDriver struct {
+	mu      sync.Mutex
+	nodes   map[string]*Node
+	nextID  int
+	DefUser string
+}
+
+// NewMockDriver returns a MockDriver ready for use.
+func NewMockDriver() *MockDriver {
+	return &MockDriver{nodes: make(map[string]*Node), DefUser: "root"}
+}
+
+func (d *MockDriver) Create(_ context.Context, req *CreateRequest) (*Node, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.nextID++
+	n := &Node{ID: fmt.Sprintf("mock-%d", d.nextID), Name: req.Name}
+	n.Networks.V4 = []string{"10.0.0.1"}
+	d.nodes[n.ID] = n
+
+	return n, nil
+}
+
+func (d *MockDriver) Destroy(_ context.Context, id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.nodes[id]; !ok {
+		return fmt.Errorf("mock: no such node %s", id)
+	}
+	delete(d.nodes, id)
+
+	return nil
+}
+
+func (d *MockDriver) List(_ context.Context) ([]*Node, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	nodes := make([]*Node, 0, len(d.nodes))
+	for _, n := range d.nodes {
+		nodes = append(nodes, n)
+	}
+
+	return nodes, nil
+}
+
+func (d *MockDriver) Get(_ context.Context, id string) (*Node, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	n, ok := d.nodes[id]
+	if !ok {
+		return nil, fmt.Errorf("mock: no such node %s", id)
+	}
+
+	return n, nil
+}
+
+func (d *MockDriver) DefaultUser() string {
+	return d.DefUser
+}
@@ -0,0 +1,89 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// BillingType describes how a node is billed by the provider.
+type BillingType string
+
+const (
+	Hourly  BillingType = "hourly"
+	Monthly BillingType = "monthly"
+)
+
+// CreateRequest describes the parameters needed to provision a new node.
+type CreateRequest struct {
+	User        string
+	Name        string
+	Region      string
+	SKU         string
+	SSHKey      string
+	BillingType BillingType
+	// UserData, if set, is submitted as cloud-init user-data so the
+	// provider bootstraps the node itself instead of the caller having
+	// to SSH in and run commands.
+	UserData string
+}
+
+// Node is a provisioned compute instance.
+type Node struct {
+	ID       string
+	Name     string
+	Networks struct {
+		V4 []string
+	}
+}
+
+// Driver provisions and manages nodes on a single cloud provider.
+type Driver interface {
+	// Create provisions a new node and returns it once the provider has
+	// assigned it an ID.
+	Create(ctx context.Context, req *CreateRequest) (*Node, error)
+	// Destroy terminates the node with the given ID.
+	Destroy(ctx context.Context, id string) error
+	// List returns every node visible to the driver's credentials.
+	List(ctx context.Context) ([]*Node, error)
+	// Get looks up a single node by ID.
+	Get(ctx context.Context, id string) (*Node, error)
+	// DefaultUser is the SSH user cloud-init provisions on this
+	// provider's default images.
+	DefaultUser() string
+}
+
+// Factory builds a Driver from a provider-specific credentials blob,
+// already unmarshaled into the shape the provider expects.
+type Factory func(credentials map[string]string) (Driver, error)
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]Factory{}
+)
+
+// Register makes a driver factory available under name, so it can be
+// selected via the config's "provider" field. It is meant to be called
+// from a driver package's init function.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic("node: driver already registered: " + name)
+	}
+	registry[name] = factory
+}
+
+// New builds the Driver registered under name using the given credentials.
+func New(name string, credentials map[string]string) (Driver, error) {
+	registryMu.Lock()
+	factory, ok := registry[name]
+	registryMu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("node: no driver registered for provider %q", name)
+	}
+
+	return factory(credentials)
+}
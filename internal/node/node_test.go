@@ -0,0 +1,40 @@
+package node
+
+import "testing"
+
+func TestRegisterAndNew(t *testing.T) {
+	const name = "test-registry"
+	Register(name, func(credentials map[string]string) (Driver, error) {
+		return NewMockDriver(), nil
+	})
+
+	driver, err := New(name, nil)
+	if err != nil {
+		t.Fatalf("New(%q): unexpected error: %v", name, err)
+	}
+	if _, ok := driver.(*MockDriver); !ok {
+		t.Fatalf("New(%q): got %T, want *MockDriver", name, driver)
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	const name = "test-registry-dup"
+	Register(name, func(credentials map[string]string) (Driver, error) {
+		return NewMockDriver(), nil
+	})
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Register: expected panic on duplicate registration, got none")
+		}
+	}()
+	Register(name, func(credentials map[string]string) (Driver, error) {
+		return NewMockDriver(), nil
+	})
+}
+
+func TestNewUnregisteredProvider(t *testing.T) {
+	if _, err := New("no-such-provider", nil); err == nil {
+		t.Fatal("New: expected error for unregistered provider, got nil")
+	}
+}
@@ -0,0 +1,176 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// OVHDriver provisions nodes against the OVH public cloud API.
+type OVHDriver struct {
+	client    *ovh.Client
+	projectID string
+}
+
+// NewOVHDriver builds an OVHDriver authenticated against the given endpoint
+// (e.g. "ovh-ca", "ovh-eu") using the provided application credentials.
+func NewOVHDriver(endpoint, appKey, appSecret, consumerKey, projectID string) (*OVHDriver, error) {
+	client, err := ovh.NewClient(endpoint, appKey, appSecret, consumerKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ovh client: %w", err)
+	}
+
+	return &OVHDriver{client: client, projectID: projectID}, nil
+}
+
+func init() {
+	Register("ovh", func(credentials map[string]string) (Driver, error) {
+		return NewOVHDriver(
+			credentials["endpoint"],
+			credentials["app_key"],
+			credentials["app_secret"],
+			credentials["consumer_key"],
+			credentials["project_id"],
+		)
+	})
+}
+
+// DefaultUser returns the username cloud-init provisions by default on OVH
+// public cloud images.
+func (d *OVHDriver) DefaultUser() string {
+	return "ubuntu"
+}
+
+type ovhCreateInstanceRequest struct {
+	Name          string `json:"name"`
+	FlavorName    string `json:"flavorName"`
+	Region        string `json:"region"`
+	ImageID       string `json:"imageId"`
+	SSHKeyName    string `json:"sshKeyName,omitempty"`
+	MonthlyBilled bool   `json:"monthlyBilling"`
+	UserData      string `json:"userData,omitempty"`
+}
+
+type ovhInstance struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Networks []struct {
+		IP   string `json:"ip"`
+		Type string `json:"type"`
+	} `json:"ipAddresses"`
+}
+
+// ovhSSHKey mirrors the OVH project SSH key resource. Instances reference
+// a key by name, not by its public key content, so it has to exist as one
+// of these before it can be attached to a Create call.
+type ovhSSHKey struct {
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name"`
+	PublicKey string `json:"publicKey,omitempty"`
+}
+
+// ensureSSHKey makes sure a project SSH key named name exists, creating it
+// from publicKey if necessary, and returns the name to use as an instance's
+// sshKeyName. An existing key is matched by name only; its content is not
+// compared with publicKey.
+func (d *OVHDriver) ensureSSHKey(ctx context.Context, name, publicKey string) (string, error) {
+	path := fmt.Sprintf("/cloud/project/%s/sshkey", d.projectID)
+
+	var keys []ovhSSHKey
+	if err := d.client.GetWithContext(ctx, path, &keys); err != nil {
+		return "", fmt.Errorf("failed to list ssh keys: %w", err)
+	}
+	for _, k := range keys {
+		if k.Name == name {
+			return k.Name, nil
+		}
+	}
+
+	var created ovhSSHKey
+	body := &ovhSSHKey{Name: name, PublicKey: publicKey}
+	if err := d.client.PostWithContext(ctx, path, body, &created); err != nil {
+		return "", fmt.Errorf("failed to register ssh key %s: %w", name, err)
+	}
+
+	return created.Name, nil
+}
+
+// Create provisions a new instance in the OVH project configured on the
+// driver and waits for it to be assigned an ID.
+func (d *OVHDriver) Create(ctx context.Context, req *CreateRequest) (*Node, error) {
+	var keyName string
+	if req.SSHKey != "" {
+		var err error
+		keyName, err = d.ensureSSHKey(ctx, req.Name, req.SSHKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	body := &ovhCreateInstanceRequest{
+		Name:          req.Name,
+		FlavorName:    req.SKU,
+		Region:        req.Region,
+		ImageID:       "Ubuntu 20.04",
+		SSHKeyName:    keyName,
+		MonthlyBilled: req.BillingType == Monthly,
+		UserData:      req.UserData,
+	}
+
+	var resp ovhInstance
+	path := fmt.Sprintf("/cloud/project/%s/instance", d.projectID)
+	if err := d.client.PostWithContext(ctx, path, body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to create instance: %w", err)
+	}
+
+	return resp.toNode(), nil
+}
+
+// Destroy terminates the instance with the given ID.
+func (d *OVHDriver) Destroy(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/cloud/project/%s/instance/%s", d.projectID, id)
+	if err := d.client.DeleteWithContext(ctx, path, nil); err != nil {
+		return fmt.Errorf("failed to destroy instance %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// List returns every instance in the configured OVH project.
+func (d *OVHDriver) List(ctx context.Context) ([]*Node, error) {
+	var resp []ovhInstance
+	path := fmt.Sprintf("/cloud/project/%s/instance", d.projectID)
+	if err := d.client.GetWithContext(ctx, path, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list instances: %w", err)
+	}
+
+	nodes := make([]*Node, 0, len(resp))
+	for _, inst := range resp {
+		nodes = append(nodes, inst.toNode())
+	}
+
+	return nodes, nil
+}
+
+// Get looks up a single instance by ID.
+func (d *OVHDriver) Get(ctx context.Context, id string) (*Node, error) {
+	var resp ovhInstance
+	path := fmt.Sprintf("/cloud/project/%s/instance/%s", d.projectID, id)
+	if err := d.client.GetWithContext(ctx, path, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get instance %s: %w", id, err)
+	}
+
+	return resp.toNode(), nil
+}
+
+func (inst *ovhInstance) toNode() *Node {
+	n := &Node{ID: inst.ID, Name: inst.Name}
+	for _, net := range inst.Networks {
+		if net.Type == "public" {
+			n.Networks.V4 = append(n.Networks.V4, net.IP)
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,149 @@
+// Package scaleway implements a node.Driver backed by the Scaleway instance API.
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+
+	"github.com/jbpratt/stk-memes/internal/node"
+)
+
+func init() {
+	node.Register("scaleway", func(credentials map[string]string) (node.Driver, error) {
+		return NewDriver(credentials["access_key"], credentials["secret_key"], credentials["project_id"])
+	})
+}
+
+// Driver provisions nodes against the Scaleway instance API.
+type Driver struct {
+	api       *instance.API
+	iam       *iam.API
+	projectID string
+}
+
+// NewDriver builds a Driver authenticated with the given access/secret key
+// pair, scoped to the given project.
+func NewDriver(accessKey, secretKey, projectID string) (*Driver, error) {
+	client, err := scw.NewClient(
+		scw.WithAuth(accessKey, secretKey),
+		scw.WithDefaultProjectID(projectID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scaleway client: %w", err)
+	}
+
+	return &Driver{api: instance.NewAPI(client), iam: iam.NewAPI(client), projectID: projectID}, nil
+}
+
+// DefaultUser returns the username Scaleway's stock Ubuntu images log in as.
+func (d *Driver) DefaultUser() string {
+	return "root"
+}
+
+// ensureSSHKey makes sure an SSH key named name is registered for the
+// project, registering publicKey under that name if necessary. An existing
+// key is matched by name only; its content is not compared with publicKey.
+// Unlike the other drivers, Scaleway has no per-instance SSH key field on
+// CreateServerRequest: every key registered for the project is authorized
+// on all of its instances automatically (via the IAM API, not the
+// instance API), so the key only has to be registered rather than being
+// threaded through Create's request body.
+func (d *Driver) ensureSSHKey(ctx context.Context, name, publicKey string) error {
+	resp, err := d.iam.ListSSHKeys(&iam.ListSSHKeysRequest{ProjectID: &d.projectID, Name: &name}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to list ssh keys: %w", err)
+	}
+	for _, k := range resp.SSHKeys {
+		if k.Name == name {
+			return nil
+		}
+	}
+
+	if _, err := d.iam.CreateSSHKey(&iam.CreateSSHKeyRequest{Name: name, PublicKey: publicKey, ProjectID: d.projectID}, scw.WithContext(ctx)); err != nil {
+		return fmt.Errorf("failed to register ssh key %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Create provisions a new instance server.
+func (d *Driver) Create(ctx context.Context, req *node.CreateRequest) (*node.Node, error) {
+	if req.SSHKey != "" {
+		if err := d.ensureSSHKey(ctx, req.Name, req.SSHKey); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := d.api.CreateServer(&instance.CreateServerRequest{
+		Name:              req.Name,
+		CommercialType:    req.SKU,
+		Zone:              scw.Zone(req.Region),
+		Image:             scw.StringPtr("ubuntu_focal"),
+		DynamicIPRequired: scw.BoolPtr(true),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server: %w", err)
+	}
+
+	if req.UserData != "" {
+		err := d.api.SetServerUserData(&instance.SetServerUserDataRequest{
+			Zone:     resp.Server.Zone,
+			ServerID: resp.Server.ID,
+			Key:      "cloud-init",
+			Content:  strings.NewReader(req.UserData),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to set user-data on server %s: %w", resp.Server.ID, err)
+		}
+	}
+
+	return toNode(resp.Server), nil
+}
+
+// Destroy deletes the server with the given ID.
+func (d *Driver) Destroy(ctx context.Context, id string) error {
+	if err := d.api.DeleteServer(&instance.DeleteServerRequest{ServerID: id}, scw.WithContext(ctx)); err != nil {
+		return fmt.Errorf("failed to delete server %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// List returns every instance server in the project.
+func (d *Driver) List(ctx context.Context) ([]*node.Node, error) {
+	resp, err := d.api.ListServers(&instance.ListServersRequest{Project: &d.projectID}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	nodes := make([]*node.Node, 0, len(resp.Servers))
+	for _, s := range resp.Servers {
+		nodes = append(nodes, toNode(s))
+	}
+
+	return nodes, nil
+}
+
+// Get looks up a single server by ID.
+func (d *Driver) Get(ctx context.Context, id string) (*node.Node, error) {
+	resp, err := d.api.GetServer(&instance.GetServerRequest{ServerID: id}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server %s: %w", id, err)
+	}
+
+	return toNode(resp.Server), nil
+}
+
+func toNode(s *instance.Server) *node.Node {
+	n := &node.Node{ID: s.ID, Name: s.Name}
+	if s.PublicIP != nil {
+		n.Networks.V4 = []string{s.PublicIP.Address.String()}
+	}
+
+	return n
+}
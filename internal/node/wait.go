@@ -0,0 +1,63 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// WaitForSSH polls host:port with exponential backoff until it accepts a TCP
+// connection and offers an SSH banner, or returns an error once timeout
+// elapses. Cloud-init can take far longer than a fixed sleep to bring SSH up,
+// so callers should pass a generous timeout.
+func WaitForSSH(ctx context.Context, host string, port int, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	addr := fmt.Sprintf("%s:%d", host, port)
+
+	backoff := time.Second
+	const maxBackoff = 30 * time.Second
+
+	for {
+		if err := probeSSHBanner(ctx, addr); err == nil {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("node: timed out waiting for ssh on %s", addr)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func probeSSHBanner(ctx context.Context, addr string) error {
+	d := net.Dialer{Timeout: 5 * time.Second}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	banner := make([]byte, 255)
+	n, err := conn.Read(banner)
+	if err != nil {
+		return fmt.Errorf("no ssh banner: %w", err)
+	}
+	if !bytes.HasPrefix(banner[:n], []byte("SSH-")) {
+		return fmt.Errorf("unexpected banner: %q", banner[:n])
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+// Package prompt reads secrets from the controlling terminal instead of
+// requiring them on the command line or in a config file, where they'd leak
+// into shell history and logs.
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// AskSecret prints prompt to stderr and reads a line with echo disabled,
+// restoring terminal state before returning. When stdin isn't a TTY (e.g.
+// in CI) it falls back to reading a plain line from it.
+func AskSecret(prompt string) ([]byte, error) {
+	fmt.Fprint(os.Stderr, prompt)
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("prompt: failed to read secret: %w", err)
+		}
+
+		return []byte(strings.TrimRight(line, "\r\n")), nil
+	}
+
+	secret, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("prompt: failed to read secret: %w", err)
+	}
+
+	return secret, nil
+}
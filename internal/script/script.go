@@ -0,0 +1,310 @@
+// Package script runs Starlark provisioning scripts against a node driver,
+// exposing builtins for creating nodes and driving them over SSH.
+package script
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/jbpratt/stk-memes/internal/node"
+)
+
+// Runner executes a provisioning script against a single node driver,
+// dialing SSH with the given user, signer and host key callback for
+// run/copy calls.
+type Runner struct {
+	ctx       context.Context
+	provider  string
+	driver    node.Driver
+	user      string
+	signer    ssh.Signer
+	hostKeyCB ssh.HostKeyCallback
+
+	nodes map[string]*node.Node
+}
+
+// NewRunner builds a Runner that provisions nodes on the named provider
+// through driver, authenticating to them as user using signer and verifying
+// host keys with hostKeyCB.
+func NewRunner(ctx context.Context, provider string, driver node.Driver, user string, signer ssh.Signer, hostKeyCB ssh.HostKeyCallback) *Runner {
+	return &Runner{
+		ctx:       ctx,
+		provider:  provider,
+		driver:    driver,
+		user:      user,
+		signer:    signer,
+		hostKeyCB: hostKeyCB,
+		nodes:     make(map[string]*node.Node),
+	}
+}
+
+// Nodes returns every node the script created, in no particular order.
+func (r *Runner) Nodes() []*node.Node {
+	nodes := make([]*node.Node, 0, len(r.nodes))
+	for _, n := range r.nodes {
+		nodes = append(nodes, n)
+	}
+
+	return nodes
+}
+
+// Run executes the Starlark script at path. globals are merged in as
+// additional predeclared names, on top of the builtins (node, ssh_wait,
+// run, copy, write, destroy).
+func (r *Runner) Run(path string, globals starlark.StringDict) error {
+	thread := &starlark.Thread{
+		Name: "stk-memes",
+		Print: func(_ *starlark.Thread, msg string) {
+			fmt.Println(msg)
+		},
+	}
+
+	predeclared := starlark.StringDict{
+		"node":     starlark.NewBuiltin("node", r.nodeBuiltin),
+		"ssh_wait": starlark.NewBuiltin("ssh_wait", r.sshWaitBuiltin),
+		"run":      starlark.NewBuiltin("run", r.runBuiltin),
+		"copy":     starlark.NewBuiltin("copy", r.copyBuiltin),
+		"write":    starlark.NewBuiltin("write", r.writeBuiltin),
+		"destroy":  starlark.NewBuiltin("destroy", r.destroyBuiltin),
+	}
+	for name, val := range globals {
+		predeclared[name] = val
+	}
+
+	_, err := starlark.ExecFile(thread, path, nil, predeclared)
+	return err
+}
+
+func (r *Runner) nodeBuiltin(_ *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var provider, region, sku, name, sshKey, userData string
+	if err := starlark.UnpackArgs("node", args, kwargs,
+		"provider", &provider,
+		"region", &region,
+		"sku", &sku,
+		"name?", &name,
+		"ssh_key?", &sshKey,
+		"user_data?", &userData,
+	); err != nil {
+		return nil, err
+	}
+
+	if provider != r.provider {
+		return nil, fmt.Errorf("node: script asked for provider %q but this runner is configured for %q", provider, r.provider)
+	}
+	if name == "" {
+		name = "stk-memes"
+	}
+
+	req := &node.CreateRequest{
+		User:        r.driver.DefaultUser(),
+		Name:        name,
+		Region:      region,
+		SKU:         sku,
+		SSHKey:      sshKey,
+		BillingType: node.Hourly,
+		UserData:    userData,
+	}
+
+	fmt.Printf("creating node %q in %s (%s)\n", name, region, sku)
+	n, err := r.driver.Create(r.ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("node: create failed: %w", err)
+	}
+	r.nodes[n.ID] = n
+
+	if len(n.Networks.V4) == 0 {
+		return nil, fmt.Errorf("node: %s was created without a public IP", n.ID)
+	}
+
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"id":   starlark.String(n.ID),
+		"host": starlark.String(n.Networks.V4[0]),
+	}), nil
+}
+
+func (r *Runner) sshWaitBuiltin(_ *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var host string
+	timeout := 300
+	if err := starlark.UnpackArgs("ssh_wait", args, kwargs, "host", &host, "timeout?", &timeout); err != nil {
+		return nil, err
+	}
+
+	if err := node.WaitForSSH(r.ctx, host, 22, time.Duration(timeout)*time.Second); err != nil {
+		return nil, fmt.Errorf("ssh_wait: %w", err)
+	}
+
+	return starlark.None, nil
+}
+
+func (r *Runner) dial(host string) (*ssh.Client, error) {
+	conf := &ssh.ClientConfig{
+		User:            r.user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(r.signer)},
+		HostKeyCallback: r.hostKeyCB,
+		Timeout:         10 * time.Second,
+	}
+
+	conn, err := ssh.Dial("tcp", net.JoinHostPort(host, "22"), conf)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", host, err)
+	}
+
+	return conn, nil
+}
+
+func (r *Runner) runBuiltin(_ *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var host, cmd string
+	ignoreError := false
+	if err := starlark.UnpackArgs("run", args, kwargs, "host", &host, "cmd", &cmd, "ignore_error?", &ignoreError); err != nil {
+		return nil, err
+	}
+
+	conn, err := r.dial(host)
+	if err != nil {
+		return nil, fmt.Errorf("run: %w", err)
+	}
+	defer conn.Close()
+
+	session, err := conn.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("run: new session: %w", err)
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+
+	fmt.Printf("+ %s@%s: %s\n", r.user, host, cmd)
+	runErr := session.Run(cmd)
+	os.Stdout.Write(stdout.Bytes())
+	os.Stderr.Write(stderr.Bytes())
+
+	if runErr != nil && !ignoreError {
+		return nil, fmt.Errorf("run: %q on %s: %w", cmd, host, runErr)
+	}
+
+	return starlark.None, nil
+}
+
+func (r *Runner) copyBuiltin(_ *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var host, src, dst string
+	if err := starlark.UnpackArgs("copy", args, kwargs, "host", &host, "src", &src, "dst", &dst); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return nil, fmt.Errorf("copy: read %s: %w", src, err)
+	}
+
+	conn, err := r.dial(host)
+	if err != nil {
+		return nil, fmt.Errorf("copy: %w", err)
+	}
+	defer conn.Close()
+
+	session, err := conn.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("copy: new session: %w", err)
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("copy: stdin pipe: %w", err)
+	}
+
+	fmt.Printf("+ copying %s to %s@%s:%s\n", src, r.user, host, dst)
+	if err := session.Start(fmt.Sprintf("cat > %s", dst)); err != nil {
+		return nil, fmt.Errorf("copy: start: %w", err)
+	}
+
+	if _, err := stdin.Write(data); err != nil {
+		return nil, fmt.Errorf("copy: write: %w", err)
+	}
+	stdin.Close()
+
+	if err := session.Wait(); err != nil {
+		return nil, fmt.Errorf("copy: %s to %s:%s: %w", src, host, dst, err)
+	}
+
+	return starlark.None, nil
+}
+
+// writeBuiltin writes content straight to dst on host over SSH, never
+// touching local disk, so secrets passed as content aren't left sitting in
+// a file on the operator's machine. mode restricts the remote file's
+// permissions from the moment it's created.
+func (r *Runner) writeBuiltin(_ *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var host, content, dst string
+	mode := "0644"
+	if err := starlark.UnpackArgs("write", args, kwargs, "host", &host, "content", &content, "dst", &dst, "mode?", &mode); err != nil {
+		return nil, err
+	}
+
+	conn, err := r.dial(host)
+	if err != nil {
+		return nil, fmt.Errorf("write: %w", err)
+	}
+	defer conn.Close()
+
+	session, err := conn.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("write: new session: %w", err)
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("write: stdin pipe: %w", err)
+	}
+
+	fmt.Printf("+ writing %s@%s:%s (mode %s)\n", r.user, host, dst, mode)
+	if err := session.Start(fmt.Sprintf("install -m %s /dev/stdin %s", mode, dst)); err != nil {
+		return nil, fmt.Errorf("write: start: %w", err)
+	}
+
+	if _, err := stdin.Write([]byte(content)); err != nil {
+		return nil, fmt.Errorf("write: write: %w", err)
+	}
+	stdin.Close()
+
+	if err := session.Wait(); err != nil {
+		return nil, fmt.Errorf("write: %s:%s: %w", host, dst, err)
+	}
+
+	return starlark.None, nil
+}
+
+func (r *Runner) destroyBuiltin(_ *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var n *starlarkstruct.Struct
+	if err := starlark.UnpackArgs("destroy", args, kwargs, "node", &n); err != nil {
+		return nil, err
+	}
+
+	idVal, err := n.Attr("id")
+	if err != nil {
+		return nil, fmt.Errorf("destroy: %w", err)
+	}
+	id, ok := starlark.AsString(idVal)
+	if !ok {
+		return nil, fmt.Errorf("destroy: node.id is not a string")
+	}
+
+	fmt.Printf("destroying node %s\n", id)
+	if err := r.driver.Destroy(r.ctx, id); err != nil {
+		return nil, fmt.Errorf("destroy: %w", err)
+	}
+	delete(r.nodes, id)
+
+	return starlark.None, nil
+}
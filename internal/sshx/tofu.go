@@ -0,0 +1,68 @@
+// Package sshx provides trust-on-first-use host key verification for SSH
+// clients, backed by a known_hosts-format file.
+package sshx
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// TOFUCallback returns an ssh.HostKeyCallback backed by the known_hosts-format
+// file at path. The first connection to a host records its host key;
+// later connections are verified against the stored key and fail loudly on
+// mismatch.
+func TOFUCallback(path string) (ssh.HostKeyCallback, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("sshx: failed to create known_hosts dir: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("sshx: failed to create known_hosts file: %w", err)
+	}
+	f.Close()
+
+	verify, err := knownhosts.New(path)
+	if err != nil {
+		return nil, fmt.Errorf("sshx: failed to load known_hosts: %w", err)
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := verify(hostname, remote, key)
+		if err == nil {
+			return nil
+		}
+
+		var keyErr *knownhosts.KeyError
+		if !errors.As(err, &keyErr) || len(keyErr.Want) > 0 {
+			return fmt.Errorf("sshx: host key verification failed for %s: %w", hostname, err)
+		}
+
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("sshx: failed to open known_hosts: %w", err)
+		}
+		defer f.Close()
+
+		line := knownhosts.Line([]string{knownhosts.Normalize(hostname)}, key)
+		if _, err := fmt.Fprintln(f, line); err != nil {
+			return fmt.Errorf("sshx: failed to record host key for %s: %w", hostname, err)
+		}
+
+		// Reload so later connections in this process are verified against
+		// the key just recorded.
+		reloaded, err := knownhosts.New(path)
+		if err != nil {
+			return fmt.Errorf("sshx: failed to reload known_hosts: %w", err)
+		}
+		verify = reloaded
+
+		return nil
+	}, nil
+}
@@ -0,0 +1,123 @@
+// Package state tracks the deployments stk-memes has provisioned, so nodes
+// can be looked up and destroyed later by name instead of only through the
+// provider's own console.
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Record is everything needed to find and tear down a previously
+// provisioned node again.
+type Record struct {
+	Name         string    `json:"name"`
+	Provider     string    `json:"provider"`
+	NodeID       string    `json:"node_id"`
+	Host         string    `json:"host"`
+	User         string    `json:"user"`
+	IdentityFile string    `json:"identity_file"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Store is a JSON file of Records keyed by deployment name.
+type Store struct {
+	path string
+
+	mu      sync.Mutex
+	records map[string]Record
+}
+
+// DefaultPath returns ~/.config/stk-memes/state.json.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve config dir: %w", err)
+	}
+
+	return filepath.Join(dir, "stk-memes", "state.json"), nil
+}
+
+// Open loads the store at path, creating an empty one if it doesn't exist yet.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, records: make(map[string]Record)}
+
+	contents, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	if err := json.Unmarshal(contents, &s.records); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state file: %w", err)
+	}
+
+	return s, nil
+}
+
+// Get returns the record for name, if any.
+func (s *Store) Get(name string) (Record, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.records[name]
+	return r, ok
+}
+
+// List returns every record in the store.
+func (s *Store) List() []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records := make([]Record, 0, len(s.records))
+	for _, r := range s.records {
+		records = append(records, r)
+	}
+
+	return records
+}
+
+// Put adds or replaces the record for r.Name and persists the store.
+func (s *Store) Put(r Record) error {
+	s.mu.Lock()
+	s.records[r.Name] = r
+	s.mu.Unlock()
+
+	return s.save()
+}
+
+// Delete removes the record for name and persists the store. It is a no-op
+// if name isn't present.
+func (s *Store) Delete(name string) error {
+	s.mu.Lock()
+	delete(s.records, name)
+	s.mu.Unlock()
+
+	return s.save()
+}
+
+func (s *Store) save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state dir: %w", err)
+	}
+
+	contents, err := json.MarshalIndent(s.records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, contents, 0o600); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/jbpratt/stk-memes/internal/state"
+)
+
+func resolveStatePath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+
+	return state.DefaultPath()
+}
+
+func openStore(path string) (*state.Store, error) {
+	path, err := resolveStatePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.Open(path)
+}
+
+// knownHostsPath returns the TOFU known_hosts file to use alongside the
+// state file at statePath.
+func knownHostsPath(statePath string) (string, error) {
+	path, err := resolveStatePath(statePath)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(path), "known_hosts"), nil
+}